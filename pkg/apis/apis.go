@@ -0,0 +1,17 @@
+// Package apis contains Kubernetes API groups.
+package apis
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+)
+
+// AddToSchemes may be used to add all resources defined in the project to a Scheme.
+var AddToSchemes runtime.SchemeBuilder
+
+// AddToScheme adds all Resources to the Scheme.
+func AddToScheme(s *runtime.Scheme) error {
+	AddToSchemes = append(AddToSchemes, v1alpha1.AddToScheme)
+	return AddToSchemes.AddToScheme(s)
+}
@@ -0,0 +1,233 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterAutoscaler represents a single cluster-autoscaler deployment that
+// manages autoscaling for a cluster.
+type ClusterAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterAutoscalerSpec   `json:"spec"`
+	Status ClusterAutoscalerStatus `json:"status,omitempty"`
+}
+
+// ClusterAutoscalerList is a list of ClusterAutoscaler resources.
+type ClusterAutoscalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterAutoscaler `json:"items"`
+}
+
+// ClusterAutoscalerSpec defines the desired state of a ClusterAutoscaler.
+type ClusterAutoscalerSpec struct {
+	// MaxPodGracePeriod is the maximum time, in seconds, to wait for pod
+	// termination when trying to scale down a node.
+	MaxPodGracePeriod *int32 `json:"maxPodGracePeriod,omitempty"`
+
+	// PodPriorityThreshold enables users to provide a threshold, in terms
+	// of pod priority, that a pod needs to meet to be considered for
+	// scale up/down.
+	PodPriorityThreshold *int32 `json:"podPriorityThreshold,omitempty"`
+
+	// ResourceLimits configures the resource limits of the autoscaler.
+	ResourceLimits *ResourceLimits `json:"resourceLimits,omitempty"`
+
+	// ScaleDown configures scale down behavior of the autoscaler.
+	ScaleDown *ScaleDownConfig `json:"scaleDown,omitempty"`
+
+	// Image overrides the cluster-autoscaler image the operator deploys.
+	// If unset, the operator's built-in default image is used.
+	Image *string `json:"image,omitempty"`
+
+	// VerticalPodAutoscaler, if set, opts the cluster-autoscaler pod
+	// itself into being resource-sized by a VerticalPodAutoscaler that
+	// the operator creates and owns.
+	VerticalPodAutoscaler *VerticalPodAutoscalerConfig `json:"verticalPodAutoscaler,omitempty"`
+}
+
+// VPAUpdateMode mirrors the update modes accepted by
+// autoscaling.k8s.io/v1 VerticalPodAutoscaler.Spec.UpdatePolicy.
+type VPAUpdateMode string
+
+const (
+	// VPAUpdateModeOff means the VPA only recommends resources; nothing
+	// changes them automatically.
+	VPAUpdateModeOff VPAUpdateMode = "Off"
+
+	// VPAUpdateModeInitial means the VPA only sets resources when a pod
+	// is created.
+	VPAUpdateModeInitial VPAUpdateMode = "Initial"
+
+	// VPAUpdateModeAuto means the VPA may evict and recreate the pod to
+	// apply updated resources.
+	VPAUpdateModeAuto VPAUpdateMode = "Auto"
+)
+
+// VerticalPodAutoscalerConfig configures the VerticalPodAutoscaler the
+// operator creates for the cluster-autoscaler Deployment.
+type VerticalPodAutoscalerConfig struct {
+	// UpdateMode selects how the VPA applies its recommendations.
+	// Defaults to "Auto" if unset.
+	UpdateMode *VPAUpdateMode `json:"updateMode,omitempty"`
+
+	// MinAllowed sets a floor on the resources the VPA may recommend.
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// MaxAllowed sets a ceiling on the resources the VPA may recommend.
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// ResourceLimits defines limits for resources, such as CPU and memory, that
+// can be managed by the autoscaler.
+type ResourceLimits struct {
+	// MaxNodesTotal limits the maximum number of nodes in the cluster.
+	MaxNodesTotal *int32 `json:"maxNodesTotal,omitempty"`
+
+	// Cores limits the number of cores in the cluster.
+	Cores *ResourceRange `json:"cores,omitempty"`
+
+	// Memory limits the amount of memory, in GiB, in the cluster.
+	Memory *ResourceRange `json:"memory,omitempty"`
+
+	// GPUS limits the number of different GPUs in the cluster.
+	GPUS []GPULimit `json:"gpus,omitempty"`
+}
+
+// ResourceRange defines a range, with a minimum and maximum, for a given
+// resource.
+type ResourceRange struct {
+	Min int32 `json:"min"`
+	Max int32 `json:"max"`
+}
+
+// GPULimit defines a resource range for a specific GPU type.
+type GPULimit struct {
+	// Type is the type of GPU, e.g. "nvidia.com/gpu".
+	Type string `json:"type"`
+
+	Min int32 `json:"min"`
+	Max int32 `json:"max"`
+}
+
+// ScaleDownConfig defines the scale down behaviour of the autoscaler.
+type ScaleDownConfig struct {
+	// Enabled controls whether the autoscaler is allowed to scale down
+	// the cluster.
+	Enabled bool `json:"enabled"`
+
+	// DelayAfterAdd sets the duration the autoscaler should wait after
+	// scaling up before scaling down.
+	DelayAfterAdd *string `json:"delayAfterAdd,omitempty"`
+
+	// UnneededTime sets the duration a node should be unneeded before it
+	// is eligible for scale down.
+	UnneededTime *string `json:"unneededTime,omitempty"`
+}
+
+// ClusterAutoscalerConditionType are the valid condition types for a
+// ClusterAutoscaler.
+type ClusterAutoscalerConditionType string
+
+const (
+	// ClusterAutoscalerAvailable indicates that the cluster-autoscaler
+	// Deployment is available, i.e. it has at least one available
+	// replica that is running with the most recent Deployment spec.
+	ClusterAutoscalerAvailable ClusterAutoscalerConditionType = "Available"
+
+	// ClusterAutoscalerProgressing indicates that the cluster-autoscaler
+	// Deployment is in the process of rolling out.
+	ClusterAutoscalerProgressing ClusterAutoscalerConditionType = "Progressing"
+
+	// ClusterAutoscalerReplicaFailure indicates that the
+	// cluster-autoscaler Deployment is failing to create or delete
+	// replicas.
+	ClusterAutoscalerReplicaFailure ClusterAutoscalerConditionType = "ReplicaFailure"
+
+	// ClusterAutoscalerDegraded indicates that the operator hit a
+	// ConfigurationError reconciling this ClusterAutoscaler and is
+	// backing off until the spec changes.
+	ClusterAutoscalerDegraded ClusterAutoscalerConditionType = "Degraded"
+)
+
+// ClusterAutoscalerCondition describes the state of a ClusterAutoscaler at
+// a certain point.
+type ClusterAutoscalerCondition struct {
+	// Type of the condition.
+	Type ClusterAutoscalerConditionType `json:"type"`
+
+	// Status of the condition: True, False, or Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the last time the condition transitioned
+	// from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a one-word, CamelCase reason for the condition's last
+	// transition.
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable message indicating details about the
+	// last transition.
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterAutoscalerStatus defines the observed state of a ClusterAutoscaler.
+type ClusterAutoscalerStatus struct {
+	// Conditions is a set of conditions associated with the
+	// cluster-autoscaler Deployment.
+	Conditions []ClusterAutoscalerCondition `json:"conditions,omitempty"`
+
+	// IntendedImage is the cluster-autoscaler image the operator is
+	// currently trying to roll out.
+	IntendedImage string `json:"intendedImage,omitempty"`
+
+	// ActiveImage is the cluster-autoscaler image that was last
+	// successfully rolled out to the Deployment.
+	ActiveImage string `json:"activeImage,omitempty"`
+}
+
+// GetCondition returns the condition with the given type, or nil if no such
+// condition exists.
+func (s *ClusterAutoscalerStatus) GetCondition(t ClusterAutoscalerConditionType) *ClusterAutoscalerCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == t {
+			return &s.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// SetCondition sets the given condition, updating LastTransitionTime only
+// if the status actually changed, and appending the condition if it is not
+// already present.
+func (s *ClusterAutoscalerStatus) SetCondition(c ClusterAutoscalerCondition) {
+	existing := s.GetCondition(c.Type)
+	if existing == nil {
+		c.LastTransitionTime = metav1.Now()
+		s.Conditions = append(s.Conditions, c)
+		return
+	}
+
+	if existing.Status != c.Status {
+		existing.LastTransitionTime = metav1.Now()
+	}
+
+	existing.Status = c.Status
+	existing.Reason = c.Reason
+	existing.Message = c.Message
+}
+
+// AvailableAndUpdated returns true if the Available condition is true,
+// which indicates that the cluster-autoscaler Deployment managed by this
+// ClusterAutoscaler has at least one available replica running the most
+// recently reconciled spec.
+func (s *ClusterAutoscalerStatus) AvailableAndUpdated() bool {
+	available := s.GetCondition(ClusterAutoscalerAvailable)
+	return available != nil && available.Status == corev1.ConditionTrue
+}
@@ -0,0 +1,5 @@
+// Package v1alpha1 contains API Schema definitions for the autoscaling
+// v1alpha1 API group.
+// +k8s:deepcopy-gen=package,register
+// +groupName=autoscaling.openshift.io
+package v1alpha1
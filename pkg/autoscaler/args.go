@@ -0,0 +1,60 @@
+package autoscaler
+
+import (
+	"fmt"
+
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+)
+
+// AutoscalerArgs derives the cluster-autoscaler command-line arguments
+// implied by ca's spec. Fields left unset on the spec are omitted so the
+// autoscaler's own built-in defaults apply.
+func AutoscalerArgs(ca *v1alpha1.ClusterAutoscaler) []string {
+	args := []string{
+		fmt.Sprintf("--namespace=%s", ca.Namespace),
+	}
+
+	spec := ca.Spec
+
+	if spec.PodPriorityThreshold != nil {
+		args = append(args, fmt.Sprintf("--expendable-pods-priority-cutoff=%d", *spec.PodPriorityThreshold))
+	}
+
+	if spec.MaxPodGracePeriod != nil {
+		args = append(args, fmt.Sprintf("--max-graceful-termination-sec=%d", *spec.MaxPodGracePeriod))
+	}
+
+	if rl := spec.ResourceLimits; rl != nil {
+		if rl.MaxNodesTotal != nil {
+			args = append(args, fmt.Sprintf("--max-nodes-total=%d", *rl.MaxNodesTotal))
+		}
+
+		if rl.Cores != nil {
+			args = append(args, fmt.Sprintf("--cores-total=%d:%d", rl.Cores.Min, rl.Cores.Max))
+		}
+
+		if rl.Memory != nil {
+			args = append(args, fmt.Sprintf("--memory-total=%d:%d", rl.Memory.Min, rl.Memory.Max))
+		}
+
+		for _, gpu := range rl.GPUS {
+			args = append(args, fmt.Sprintf("--gpu-total=%s:%d:%d", gpu.Type, gpu.Min, gpu.Max))
+		}
+	}
+
+	if sd := spec.ScaleDown; sd != nil {
+		if !sd.Enabled {
+			args = append(args, "--scale-down-enabled=false")
+		}
+
+		if sd.DelayAfterAdd != nil {
+			args = append(args, fmt.Sprintf("--scale-down-delay-after-add=%s", *sd.DelayAfterAdd))
+		}
+
+		if sd.UnneededTime != nil {
+			args = append(args, fmt.Sprintf("--scale-down-unneeded-time=%s", *sd.UnneededTime))
+		}
+	}
+
+	return args
+}
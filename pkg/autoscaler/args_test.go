@@ -0,0 +1,100 @@
+package autoscaler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func newTestClusterAutoscaler() *v1alpha1.ClusterAutoscaler {
+	return &v1alpha1.ClusterAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: "test-namespace",
+		},
+		Spec: v1alpha1.ClusterAutoscalerSpec{
+			MaxPodGracePeriod:    int32Ptr(60),
+			PodPriorityThreshold: int32Ptr(-10),
+			ResourceLimits: &v1alpha1.ResourceLimits{
+				MaxNodesTotal: int32Ptr(100),
+				Cores:         &v1alpha1.ResourceRange{Min: 8, Max: 128},
+				Memory:        &v1alpha1.ResourceRange{Min: 4, Max: 256},
+				GPUS: []v1alpha1.GPULimit{
+					{Type: "nvidia.com/gpu", Min: 0, Max: 16},
+				},
+			},
+			ScaleDown: &v1alpha1.ScaleDownConfig{
+				Enabled:       true,
+				DelayAfterAdd: stringPtr("10m"),
+				UnneededTime:  stringPtr("10m"),
+			},
+		},
+	}
+}
+
+func includesArg(args []string, want string) bool {
+	for _, a := range args {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAutoscalerArgs(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	args := AutoscalerArgs(ca)
+
+	expected := []string{
+		"--namespace=test-namespace",
+		"--expendable-pods-priority-cutoff=-10",
+		"--max-graceful-termination-sec=60",
+		"--max-nodes-total=100",
+		"--cores-total=8:128",
+		"--memory-total=4:256",
+		"--gpu-total=nvidia.com/gpu:0:16",
+		"--scale-down-delay-after-add=10m",
+		"--scale-down-unneeded-time=10m",
+	}
+
+	for _, want := range expected {
+		if !includesArg(args, want) {
+			t.Errorf("AutoscalerArgs() = %v, missing %q", args, want)
+		}
+	}
+
+	if includesArg(args, "--scale-down-enabled=false") {
+		t.Errorf("AutoscalerArgs() should not disable scale down when Enabled is true")
+	}
+}
+
+func TestAutoscalerArgsScaleDownDisabled(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	ca.Spec.ScaleDown.Enabled = false
+
+	args := AutoscalerArgs(ca)
+	if !includesArg(args, "--scale-down-enabled=false") {
+		t.Errorf("AutoscalerArgs() = %v, want --scale-down-enabled=false", args)
+	}
+}
+
+func TestAutoscalerArgsMinimal(t *testing.T) {
+	ca := &v1alpha1.ClusterAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "test-namespace"},
+	}
+
+	args := AutoscalerArgs(ca)
+	if len(args) != 1 || !strings.HasPrefix(args[0], "--namespace=") {
+		t.Errorf("AutoscalerArgs() for an empty spec = %v, want only --namespace", args)
+	}
+}
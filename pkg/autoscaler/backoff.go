@@ -0,0 +1,49 @@
+package autoscaler
+
+import (
+	"time"
+
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// configurationErrorBackoffBase and configurationErrorBackoffMax
+	// bound the per-CR backoff applied after a ConfigurationError, so a
+	// persistently misconfigured ClusterAutoscaler isn't reconciled as
+	// aggressively as one hitting only transient API errors.
+	configurationErrorBackoffBase = 5 * time.Minute
+	configurationErrorBackoffMax  = 30 * time.Minute
+)
+
+// configurationBackoff tracks a per-CR exponential backoff keyed on
+// namespace/name, applied only for ConfigurationError.
+type configurationBackoff struct {
+	backoff *flowcontrol.Backoff
+}
+
+func newConfigurationBackoff() *configurationBackoff {
+	return &configurationBackoff{
+		backoff: flowcontrol.NewBackOff(configurationErrorBackoffBase, configurationErrorBackoffMax),
+	}
+}
+
+func backoffKey(ca *v1alpha1.ClusterAutoscaler) string {
+	return ca.Namespace + "/" + ca.Name
+}
+
+// waiting reports whether ca is still within an active ConfigurationError
+// backoff window, i.e. Handle should skip reconciling it.
+func (b *configurationBackoff) waiting(ca *v1alpha1.ClusterAutoscaler) bool {
+	return b.backoff.IsInBackOffSinceUpdate(backoffKey(ca), time.Now())
+}
+
+// start records a new ConfigurationError for ca, extending its backoff.
+func (b *configurationBackoff) start(ca *v1alpha1.ClusterAutoscaler) {
+	b.backoff.Next(backoffKey(ca), time.Now())
+}
+
+// reset clears ca's backoff, called after a successful reconcile.
+func (b *configurationBackoff) reset(ca *v1alpha1.ClusterAutoscaler) {
+	b.backoff.Reset(backoffKey(ca))
+}
@@ -0,0 +1,33 @@
+package autoscaler
+
+import "testing"
+
+func TestConfigurationBackoff(t *testing.T) {
+	b := newConfigurationBackoff()
+	ca := newTestClusterAutoscaler()
+
+	if b.waiting(ca) {
+		t.Errorf("waiting() = true, want false before any ConfigurationError")
+	}
+
+	b.start(ca)
+	if !b.waiting(ca) {
+		t.Errorf("waiting() = false, want true immediately after start()")
+	}
+
+	b.reset(ca)
+	if b.waiting(ca) {
+		t.Errorf("waiting() = true, want false after reset()")
+	}
+}
+
+func TestNewAutoscalerError(t *testing.T) {
+	err := NewAutoscalerError(ConfigurationError, "bad value: %d", 42)
+
+	if err.Type != ConfigurationError {
+		t.Errorf("Type = %v, want %v", err.Type, ConfigurationError)
+	}
+	if err.Error() != "bad value: 42" {
+		t.Errorf("Error() = %q, want %q", err.Error(), "bad value: 42")
+	}
+}
@@ -0,0 +1,22 @@
+package autoscaler
+
+import (
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+)
+
+// client is the subset of the sdk package's API that Handle and its
+// helpers depend on, pulled out behind an interface so tests can
+// substitute an in-memory fake instead of talking to a real API server.
+type client interface {
+	Create(object sdk.Object) error
+	Get(into sdk.Object) error
+	Update(object sdk.Object) error
+}
+
+// sdkClient is the default client, backed directly by the sdk package's
+// package-level calls.
+type sdkClient struct{}
+
+func (sdkClient) Create(object sdk.Object) error { return sdk.Create(object) }
+func (sdkClient) Get(into sdk.Object) error      { return sdk.Get(into) }
+func (sdkClient) Update(object sdk.Object) error { return sdk.Update(object) }
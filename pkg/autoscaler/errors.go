@@ -0,0 +1,49 @@
+package autoscaler
+
+import "fmt"
+
+// ErrorType classifies an AutoscalerError, mirroring the error categories
+// upstream cluster-autoscaler reports from its RunOnce loop.
+type ErrorType string
+
+const (
+	// ApiCallError indicates a failure talking to the Kubernetes API. It
+	// is expected to be transient, so callers should retry aggressively.
+	ApiCallError ErrorType = "ApiCallError"
+
+	// CloudProviderError indicates a failure talking to the underlying
+	// cloud provider.
+	CloudProviderError ErrorType = "CloudProviderError"
+
+	// ConfigurationError indicates the ClusterAutoscaler spec itself is
+	// invalid or otherwise can't be satisfied. Retrying quickly won't
+	// help until the spec changes, so callers should back off.
+	ConfigurationError ErrorType = "ConfigurationError"
+
+	// DeploymentHealthError indicates the readiness check of the
+	// autoscaler Deployment failed, e.g. because of a crash-looping Pod.
+	// Handle reports every error from that check under this type,
+	// including failed API lookups made by the check itself.
+	DeploymentHealthError ErrorType = "DeploymentHealthError"
+
+	// InternalError indicates a bug in the operator itself.
+	InternalError ErrorType = "InternalError"
+)
+
+// AutoscalerError is a typed error that Handle and updateAutoscaler wrap
+// all failures through, so callers can tell a transient API hiccup apart
+// from a permanent misconfiguration.
+type AutoscalerError struct {
+	Type    ErrorType
+	Message string
+}
+
+// Error implements the error interface.
+func (e *AutoscalerError) Error() string {
+	return e.Message
+}
+
+// NewAutoscalerError builds an AutoscalerError of the given type.
+func NewAutoscalerError(t ErrorType, format string, args ...interface{}) *AutoscalerError {
+	return &AutoscalerError{Type: t, Message: fmt.Sprintf(format, args...)}
+}
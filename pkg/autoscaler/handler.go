@@ -4,80 +4,244 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+	"github.com/openshift/cluster-autoscaler-operator/pkg/statuscheck"
 	"github.com/operator-framework/operator-sdk/pkg/sdk"
-	"github.com/prometheus/client_golang/prometheus"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
 	caImage          = "quay.io/bison/cluster-autoscaler:a554b4f5"
 	criticalPod      = "scheduler.alpha.kubernetes.io/critical-pod"
 	caServiceAccount = "cluster-autoscaler"
+
+	// readinessWait bounds how long Handle waits for the autoscaler
+	// Deployment to become ready before recording its status, so a
+	// stuck rollout is surfaced as not-yet-Available rather than
+	// racing the next reconcile.
+	readinessWait = 30 * time.Second
 )
 
-func NewHandler(m *Metrics) sdk.Handler {
+func NewHandler(m *Metrics, recorder record.EventRecorder) sdk.Handler {
 	return &Handler{
-		metrics: m,
+		metrics:  m,
+		recorder: recorder,
+		backoff:  newConfigurationBackoff(),
+		client:   sdkClient{},
+		wait:     statuscheck.Wait,
 	}
 }
 
-type Metrics struct {
-	operatorErrors prometheus.Counter
-}
-
 type Handler struct {
-	metrics *Metrics
+	metrics  *Metrics
+	recorder record.EventRecorder
+	backoff  *configurationBackoff
+
+	// client and wait are indirected behind fields, rather than called as
+	// package-level functions, so tests can substitute an in-memory fake
+	// and a deterministic readiness result instead of talking to a real
+	// API server and waiting out real timeouts.
+	client client
+	wait   func(ctx context.Context, timeout time.Duration, name, namespace, serviceAccountName string) (bool, error)
 }
 
 func (h *Handler) Handle(ctx context.Context, event sdk.Event) error {
-	switch o := event.Object.(type) {
-	case *v1alpha1.ClusterAutoscaler:
-		clusterAutoscaler := o
-
-		// Ignore deletes.  Resources should have their OwnerReference
-		// set appropriately which will allow them to be garbage
-		// collected automatically.
-		if event.Deleted {
+	clusterAutoscaler, ok := event.Object.(*v1alpha1.ClusterAutoscaler)
+	if !ok {
+		return nil
+	}
+
+	// Ignore deletes.  Resources should have their OwnerReference
+	// set appropriately which will allow them to be garbage
+	// collected automatically.
+	if event.Deleted {
+		return nil
+	}
+
+	// Validate before consulting the backoff so a spec fixed while
+	// backing off is picked up on this very event rather than waiting
+	// out the rest of the window.
+	verr := validateSpec(clusterAutoscaler)
+
+	if h.backoff.waiting(clusterAutoscaler) {
+		if verr != nil {
+			// Still invalid: stay backed off without re-starting the
+			// clock or rewriting the Degraded condition every resync.
 			return nil
 		}
 
-		dep := autoscalerDeployment(clusterAutoscaler)
-		err := sdk.Create(dep)
-		if err != nil && !errors.IsAlreadyExists(err) {
-			return fmt.Errorf("failed to create autoscaler deployment: %v", err)
+		h.backoff.reset(clusterAutoscaler)
+	}
+
+	if verr != nil {
+		return h.handleError(clusterAutoscaler, verr)
+	}
+
+	h.metrics.Refresh(ctx, clusterAutoscaler)
+
+	start := time.Now()
+	defer func() {
+		h.metrics.observeReconcileDuration(clusterAutoscaler, time.Since(start))
+	}()
+
+	dep := autoscalerDeployment(clusterAutoscaler)
+	err := h.client.Create(dep)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return h.handleError(clusterAutoscaler, NewAutoscalerError(ApiCallError, "failed to create autoscaler deployment: %v", err))
+	}
+
+	if errors.IsAlreadyExists(err) {
+		if err := h.updateAutoscaler(clusterAutoscaler); err != nil {
+			return h.handleError(clusterAutoscaler, err)
 		}
 
-		if errors.IsAlreadyExists(err) {
-			return updateAutoscaler(clusterAutoscaler)
+		if err := h.client.Get(dep); err != nil {
+			return h.handleError(clusterAutoscaler, NewAutoscalerError(ApiCallError, "failed to get autoscaler deployment: %v", err))
 		}
+	}
+
+	if err := h.reconcileVPA(clusterAutoscaler); err != nil {
+		return h.handleError(clusterAutoscaler, err)
+	}
+
+	// Give a stuck rollout a chance to settle before recording status.
+	// If it's still not ready after the wait, updateStatus below records
+	// Available=False and the next reconcile tries again. A hard
+	// readiness error (e.g. a crash-looping Pod) is recorded the same
+	// way, rather than bypassing status entirely, so it's visible on the
+	// CR instead of only in the returned error.
+	ready, readyErr := h.wait(ctx, readinessWait, dep.Name, dep.Namespace, caServiceAccount)
+
+	previousActiveImage := clusterAutoscaler.Status.ActiveImage
 
-		// TODO: Update ClusterAutoscaler status.
+	// The spec passed validation, so a Degraded condition left by an
+	// earlier ConfigurationError no longer applies.
+	if degraded := clusterAutoscaler.Status.GetCondition(v1alpha1.ClusterAutoscalerDegraded); degraded != nil && degraded.Status == corev1.ConditionTrue {
+		clusterAutoscaler.Status.SetCondition(v1alpha1.ClusterAutoscalerCondition{
+			Type:   v1alpha1.ClusterAutoscalerDegraded,
+			Status: corev1.ConditionFalse,
+			Reason: "ConfigurationValid",
+		})
 	}
 
+	updateStatus(clusterAutoscaler, dep, ready)
+
+	clusterAutoscaler.Status.IntendedImage = intendedImage(clusterAutoscaler)
+
+	// Only treat the Deployment's pod spec image as "active" once its
+	// rollout has actually finished. Reading it from dep.Spec directly
+	// would flip ActiveImage to the new image on the very reconcile pass
+	// that starts the rollout, long before it's really running, masking
+	// every later transition needed to fire UpgradeCompleted.
+	rolloutComplete := ready && !rolloutInProgress(dep)
+	if rolloutComplete && len(dep.Spec.Template.Spec.Containers) > 0 {
+		clusterAutoscaler.Status.ActiveImage = dep.Spec.Template.Spec.Containers[0].Image
+	}
+
+	if rolloutComplete && clusterAutoscaler.Status.ActiveImage != previousActiveImage && clusterAutoscaler.Status.ActiveImage == clusterAutoscaler.Status.IntendedImage {
+		h.recordUpgradeEvent(clusterAutoscaler, UpgradeCompleted, fmt.Sprintf("rollout to %s completed", clusterAutoscaler.Status.IntendedImage))
+	}
+
+	if err := h.client.Update(clusterAutoscaler); err != nil {
+		return h.handleError(clusterAutoscaler, NewAutoscalerError(ApiCallError, "failed to update cluster autoscaler status: %v", err))
+	}
+
+	if readyErr != nil {
+		return h.handleError(clusterAutoscaler, NewAutoscalerError(DeploymentHealthError, "cluster-autoscaler deployment unhealthy: %v", readyErr))
+	}
+
+	h.backoff.reset(clusterAutoscaler)
+
 	return nil
 }
 
-func updateAutoscaler(ca *v1alpha1.ClusterAutoscaler) error {
+// handleError normalizes err into an *AutoscalerError (wrapping it as an
+// InternalError if it isn't already one), records it against the
+// reconcile-errors counter with its type as a label, and for a
+// ConfigurationError starts this CR's backoff and surfaces a Degraded
+// condition so operators see why reconciles have slowed down.
+func (h *Handler) handleError(ca *v1alpha1.ClusterAutoscaler, err error) error {
+	aerr, ok := err.(*AutoscalerError)
+	if !ok {
+		aerr = NewAutoscalerError(InternalError, "%v", err)
+	}
+
+	h.metrics.observeReconcileError(aerr.Type)
+
+	if aerr.Type == ConfigurationError {
+		h.backoff.start(ca)
+
+		ca.Status.SetCondition(v1alpha1.ClusterAutoscalerCondition{
+			Type:    v1alpha1.ClusterAutoscalerDegraded,
+			Status:  corev1.ConditionTrue,
+			Reason:  string(aerr.Type),
+			Message: aerr.Message,
+		})
+
+		// Best-effort: if this update fails, the Degraded condition is
+		// simply missing until the next successful reconcile; the
+		// backoff itself doesn't depend on it.
+		h.client.Update(ca) //nolint:errcheck
+	}
+
+	return aerr
+}
+
+// updateAutoscaler reconciles the autoscaler Deployment's pod spec with the
+// one derived from ca, coordinating image upgrades so that a new rollout
+// never starts on top of one that hasn't finished, and so that downgrades
+// or no-op writes don't churn the Deployment.
+func (h *Handler) updateAutoscaler(ca *v1alpha1.ClusterAutoscaler) error {
 	dep := autoscalerDeployment(ca)
-	err := sdk.Get(dep)
+	err := h.client.Get(dep)
 	if err != nil {
-		return fmt.Errorf("failed to get autoscaler deployment: %v", err)
+		return NewAutoscalerError(ApiCallError, "failed to get autoscaler deployment: %v", err)
 	}
 
 	podSpec := autoscalerPodSpec(ca)
-	if !reflect.DeepEqual(dep.Spec.Template.Spec, podSpec) {
+	if reflect.DeepEqual(dep.Spec.Template.Spec, *podSpec) {
+		return nil
+	}
+
+	active := ""
+	if len(dep.Spec.Template.Spec.Containers) > 0 {
+		active = dep.Spec.Template.Spec.Containers[0].Image
+	}
+	intended := intendedImage(ca)
+
+	if active == intended {
+		// Only the rest of the pod spec changed; no image coordination
+		// needed.
 		dep.Spec.Template.Spec = *podSpec
-		err = sdk.Update(dep)
-		if err != nil {
-			return fmt.Errorf("failed to update autoscaler deployment: %v", err)
+		if err := h.client.Update(dep); err != nil {
+			return NewAutoscalerError(ApiCallError, "failed to update autoscaler deployment: %v", err)
 		}
+		return nil
+	}
+
+	if isDowngrade(active, intended) {
+		h.recordUpgradeEvent(ca, UpgradeSkipped, fmt.Sprintf("refusing to downgrade cluster-autoscaler from %s to %s", active, intended))
+		return nil
+	}
+
+	if rolloutInProgress(dep) {
+		h.recordUpgradeEvent(ca, UpgradeSkipped, fmt.Sprintf("rollout to %s already in progress, waiting before starting rollout to %s", active, intended))
+		return nil
 	}
 
+	dep.Spec.Template.Spec = *podSpec
+	if err := h.client.Update(dep); err != nil {
+		return NewAutoscalerError(ApiCallError, "failed to update autoscaler deployment: %v", err)
+	}
+
+	h.recordUpgradeEvent(ca, UpgradeStarted, fmt.Sprintf("started rollout from %s to %s", active, intended))
+
 	return nil
 }
 
@@ -133,10 +297,11 @@ func autoscalerPodSpec(ca *v1alpha1.ClusterAutoscaler) *corev1.PodSpec {
 		ServiceAccountName: caServiceAccount,
 		Containers: []corev1.Container{
 			{
-				Name:    "cluster-autoscaler",
-				Image:   caImage,
-				Command: []string{"/cluster-autoscaler"},
-				Args:    args,
+				Name:      "cluster-autoscaler",
+				Image:     intendedImage(ca),
+				Command:   []string{"/cluster-autoscaler"},
+				Args:      args,
+				Resources: defaultResources(ca),
 			},
 		},
 		Tolerations: []corev1.Toleration{
@@ -166,15 +331,3 @@ func asOwner(ca *v1alpha1.ClusterAutoscaler) metav1.OwnerReference {
 		Controller: &trueVar,
 	}
 }
-
-func RegisterOperatorMetrics() (*Metrics, error) {
-	operatorErrors := prometheus.NewCounter(prometheus.CounterOpts{
-		Name: "memcached_operator_reconcile_errors_total",
-		Help: "Number of errors that occurred while reconciling the memcached deployment",
-	})
-	err := prometheus.Register(operatorErrors)
-	if err != nil {
-		return nil, err
-	}
-	return &Metrics{operatorErrors: operatorErrors}, nil
-}
@@ -0,0 +1,257 @@
+package autoscaler
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+)
+
+// fakeClient is a minimal in-memory stand-in for the sdk package's
+// package-level Create/Get/Update calls, keyed by type/namespace/name like
+// a real API server would key by GroupVersionKind/namespace/name.
+type fakeClient struct {
+	mu      sync.Mutex
+	objects map[fakeKey]sdk.Object
+}
+
+type fakeKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{objects: make(map[fakeKey]sdk.Object)}
+}
+
+func fakeKeyFor(o sdk.Object) fakeKey {
+	return fakeKey{kind: reflect.TypeOf(o).String(), namespace: o.GetNamespace(), name: o.GetName()}
+}
+
+func (f *fakeClient) Create(object sdk.Object) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := fakeKeyFor(object)
+	if _, ok := f.objects[key]; ok {
+		return errors.NewAlreadyExists(schema.GroupResource{}, object.GetName())
+	}
+
+	f.objects[key] = object.DeepCopyObject().(sdk.Object)
+
+	return nil
+}
+
+func (f *fakeClient) Get(into sdk.Object) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, ok := f.objects[fakeKeyFor(into)]
+	if !ok {
+		return errors.NewNotFound(schema.GroupResource{}, into.GetName())
+	}
+
+	return copyInto(into, stored)
+}
+
+// Update upserts, rather than requiring a prior Create, since Handle calls
+// it on the ClusterAutoscaler itself, which tests hand to it directly
+// rather than routing through Create first.
+func (f *fakeClient) Update(object sdk.Object) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.objects[fakeKeyFor(object)] = object.DeepCopyObject().(sdk.Object)
+
+	return nil
+}
+
+// copyInto overwrites into's fields with stored's, mirroring how a real Get
+// fills the caller's object in place.
+func copyInto(into, stored sdk.Object) error {
+	dst := reflect.ValueOf(into)
+	src := reflect.ValueOf(stored.DeepCopyObject())
+
+	if dst.Kind() != reflect.Ptr || src.Kind() != reflect.Ptr {
+		return fmt.Errorf("fakeClient: expected pointer types, got %T and %T", into, stored)
+	}
+
+	dst.Elem().Set(src.Elem())
+
+	return nil
+}
+
+// newTestMetrics returns a *Metrics with its own, unregistered collectors,
+// so tests don't collide with each other (or a real RegisterOperatorMetrics
+// call) on prometheus's default registry.
+func newTestMetrics() *Metrics {
+	return &Metrics{
+		operatorErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "test_reconcile_errors_total",
+		}, []string{"type"}),
+		reconcileDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "test_reconcile_duration_seconds",
+		}, []string{"namespace", "name"}),
+	}
+}
+
+// alwaysReady is a wait func that reports the Deployment ready with no
+// error, for tests that don't care about readiness timing.
+func alwaysReady(context.Context, time.Duration, string, string, string) (bool, error) {
+	return true, nil
+}
+
+func TestHandleCreatesDeploymentOnFirstReconcile(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	h := &Handler{
+		metrics: newTestMetrics(),
+		backoff: newConfigurationBackoff(),
+		client:  newFakeClient(),
+		wait:    alwaysReady,
+	}
+
+	if err := h.Handle(context.Background(), sdk.Event{Object: ca}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	dep := autoscalerDeployment(ca)
+	if err := h.client.Get(dep); err != nil {
+		t.Fatalf("expected Handle to have created the deployment, Get() error = %v", err)
+	}
+
+	if !ca.Status.AvailableAndUpdated() {
+		t.Errorf("AvailableAndUpdated() = false, want true once the wait func reports ready")
+	}
+}
+
+func TestHandleReValidatesBeforeConsultingBackoff(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	ca.Spec.ResourceLimits.Cores.Min, ca.Spec.ResourceLimits.Cores.Max = 128, 8 // min > max: invalid
+
+	h := &Handler{
+		metrics: newTestMetrics(),
+		backoff: newConfigurationBackoff(),
+		client:  newFakeClient(),
+		wait:    alwaysReady,
+	}
+
+	if err := h.Handle(context.Background(), sdk.Event{Object: ca}); err == nil {
+		t.Fatalf("Handle() error = nil, want a ConfigurationError for an invalid spec")
+	}
+
+	if !h.backoff.waiting(ca) {
+		t.Fatalf("backoff.waiting() = false, want true after a ConfigurationError")
+	}
+
+	// Fix the spec and re-deliver the same event, as the watch would on the
+	// very update that corrected it. Handle must pick this up immediately
+	// rather than staying backed off until the timer expires.
+	ca.Spec.ResourceLimits.Cores.Min, ca.Spec.ResourceLimits.Cores.Max = 8, 128
+
+	if err := h.Handle(context.Background(), sdk.Event{Object: ca}); err != nil {
+		t.Fatalf("Handle() error = %v, want nil once the spec is corrected", err)
+	}
+
+	dep := autoscalerDeployment(ca)
+	if err := h.client.Get(dep); err != nil {
+		t.Errorf("expected the corrected spec to be reconciled immediately, Get() error = %v", err)
+	}
+}
+
+func TestHandleClassifiesReadinessFailureAsDeploymentHealthError(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	h := &Handler{
+		metrics: newTestMetrics(),
+		backoff: newConfigurationBackoff(),
+		client:  newFakeClient(),
+		wait: func(context.Context, time.Duration, string, string, string) (bool, error) {
+			return false, fmt.Errorf("pod is crash-looping")
+		},
+	}
+
+	err := h.Handle(context.Background(), sdk.Event{Object: ca})
+	aerr, ok := err.(*AutoscalerError)
+	if !ok {
+		t.Fatalf("Handle() error = %v (%T), want an *AutoscalerError", err, err)
+	}
+
+	if aerr.Type != DeploymentHealthError {
+		t.Errorf("error type = %v, want %v for a readiness-check failure", aerr.Type, DeploymentHealthError)
+	}
+}
+
+func TestHandleFiresUpgradeCompletedOnlyAfterRolloutFinishes(t *testing.T) {
+	const oldImage = "quay.io/example/cluster-autoscaler:v1.0.0"
+	const newImage = "quay.io/example/cluster-autoscaler:v2.0.0"
+
+	ca := newTestClusterAutoscaler()
+	ca.Spec.Image = stringPtr(newImage)
+	ca.Status.ActiveImage = oldImage
+
+	// The Deployment already has the new spec (a previous reconcile
+	// started the rollout), but its status hasn't caught up: only zero
+	// of its one replica is running the new template.
+	fc := newFakeClient()
+	dep := autoscalerDeployment(ca)
+	dep.Status.Replicas = 1
+	dep.Status.UpdatedReplicas = 0
+	if err := fc.Create(dep); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	h := &Handler{
+		metrics:  newTestMetrics(),
+		recorder: recorder,
+		backoff:  newConfigurationBackoff(),
+		client:   fc,
+		wait:     alwaysReady,
+	}
+
+	if err := h.Handle(context.Background(), sdk.Event{Object: ca}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if ca.Status.ActiveImage != oldImage {
+		t.Fatalf("ActiveImage = %q, want %q while the rollout is still in progress", ca.Status.ActiveImage, oldImage)
+	}
+	select {
+	case event := <-recorder.Events:
+		t.Fatalf("unexpected event %q before the rollout has finished", event)
+	default:
+	}
+
+	// The rollout catches up.
+	if err := fc.Get(dep); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	dep.Status.UpdatedReplicas = 1
+	if err := fc.Update(dep); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if err := h.Handle(context.Background(), sdk.Event{Object: ca}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if ca.Status.ActiveImage != newImage {
+		t.Errorf("ActiveImage = %q, want %q once the rollout has finished", ca.Status.ActiveImage, newImage)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if want := "Normal " + UpgradeCompleted; !strings.HasPrefix(event, want) {
+			t.Errorf("event = %q, want it to start with %q", event, want)
+		}
+	default:
+		t.Errorf("no event recorded, want an %s event once the rollout finishes", UpgradeCompleted)
+	}
+}
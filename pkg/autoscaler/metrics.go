@@ -0,0 +1,191 @@
+package autoscaler
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+	"github.com/openshift/cluster-autoscaler-operator/pkg/observers/loopstart"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// metricsNamespace is the Prometheus namespace every metric the operator
+// exposes is registered under.
+const metricsNamespace = "cluster_autoscaler_operator"
+
+// Metrics holds the operator's Prometheus collectors. It also satisfies
+// loopstart.Observer, and delegates to a loopstart.ObserversList so
+// downstream code can register additional observers without touching
+// Handler.
+type Metrics struct {
+	observers loopstart.ObserversList
+
+	operatorErrors    *prometheus.CounterVec
+	reconcileDuration *prometheus.HistogramVec
+}
+
+// RegisterOperatorMetrics registers the operator's built-in Prometheus
+// collectors, including the loopstart observers that populate them, and
+// returns the resulting Metrics for use with NewHandler. Call ServeMetrics
+// to expose the registered collectors over HTTP.
+func RegisterOperatorMetrics() (*Metrics, error) {
+	m := &Metrics{
+		operatorErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "reconcile_errors_total",
+			Help:      "Number of errors encountered while reconciling a ClusterAutoscaler, by error type.",
+		}, []string{"type"}),
+		reconcileDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "reconcile_duration_seconds",
+			Help:      "Duration of a single ClusterAutoscaler reconcile pass.",
+		}, []string{"namespace", "name"}),
+	}
+
+	reconcileTotal := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_total",
+		Help:      "Number of reconcile passes per ClusterAutoscaler.",
+	}, []string{"namespace", "name"})
+
+	lastImage := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "last_observed_image",
+		Help:      "The cluster-autoscaler image last observed active, one series per image with value 1.",
+	}, []string{"namespace", "name", "image"})
+
+	conditions := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "deployment_condition",
+		Help:      "Status of each ClusterAutoscaler condition (1=True, 0=False, -1=Unknown).",
+	}, []string{"namespace", "name", "condition"})
+
+	collectors := []prometheus.Collector{
+		m.operatorErrors,
+		m.reconcileDuration,
+		reconcileTotal,
+		lastImage,
+		conditions,
+	}
+
+	for _, c := range collectors {
+		if err := prometheus.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	m.observers.Register(&reconcileCountObserver{counter: reconcileTotal})
+	m.observers.Register(newLastImageObserver(lastImage))
+	m.observers.Register(&conditionObserver{gauge: conditions})
+
+	return m, nil
+}
+
+// ServeMetrics starts an HTTP server on addr (e.g. ":8080") exposing every
+// collector registered with prometheus.Register, including those added by
+// RegisterOperatorMetrics, at /metrics. It blocks, so callers typically run
+// it in its own goroutine alongside the operator-sdk manager.
+func ServeMetrics(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return http.ListenAndServe(addr, mux)
+}
+
+// RegisterObserver adds an additional loopstart.Observer to be refreshed
+// at the top of every Handle call, without requiring any change to
+// Handler itself.
+func (m *Metrics) RegisterObserver(o loopstart.Observer) {
+	m.observers.Register(o)
+}
+
+// Refresh satisfies loopstart.Observer by fanning out to every registered
+// observer.
+func (m *Metrics) Refresh(ctx context.Context, ca *v1alpha1.ClusterAutoscaler) {
+	m.observers.Refresh(ctx, ca)
+}
+
+// observeReconcileDuration records how long a single reconcile pass took.
+// Unlike the other built-in observers, this can't be expressed as a
+// loopstart.Observer refreshed at the top of Handle, since it needs to
+// bracket the whole reconcile pass rather than observe a point-in-time
+// snapshot of the CR.
+func (m *Metrics) observeReconcileDuration(ca *v1alpha1.ClusterAutoscaler, d time.Duration) {
+	m.reconcileDuration.WithLabelValues(ca.Namespace, ca.Name).Observe(d.Seconds())
+}
+
+// observeReconcileError increments the reconcile-errors counter, labeled
+// with the AutoscalerError's type, so alerting can distinguish a spike in
+// transient API errors from a persistent configuration problem.
+func (m *Metrics) observeReconcileError(t ErrorType) {
+	m.operatorErrors.WithLabelValues(string(t)).Inc()
+}
+
+// reconcileCountObserver counts reconcile passes per ClusterAutoscaler.
+type reconcileCountObserver struct {
+	counter *prometheus.CounterVec
+}
+
+func (o *reconcileCountObserver) Refresh(ctx context.Context, ca *v1alpha1.ClusterAutoscaler) {
+	o.counter.WithLabelValues(ca.Namespace, ca.Name).Inc()
+}
+
+// lastImageObserver publishes the image last observed active for a
+// ClusterAutoscaler. It tracks the image it last set per CR so that when
+// the active image changes, the stale series for the old image is deleted
+// instead of left behind at value 1 forever.
+type lastImageObserver struct {
+	gauge *prometheus.GaugeVec
+
+	mu   sync.Mutex
+	last map[string]string
+}
+
+func newLastImageObserver(gauge *prometheus.GaugeVec) *lastImageObserver {
+	return &lastImageObserver{gauge: gauge, last: make(map[string]string)}
+}
+
+func (o *lastImageObserver) Refresh(ctx context.Context, ca *v1alpha1.ClusterAutoscaler) {
+	if ca.Status.ActiveImage == "" {
+		return
+	}
+
+	key := ca.Namespace + "/" + ca.Name
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if prev, ok := o.last[key]; ok && prev != ca.Status.ActiveImage {
+		o.gauge.DeleteLabelValues(ca.Namespace, ca.Name, prev)
+	}
+
+	o.last[key] = ca.Status.ActiveImage
+	o.gauge.WithLabelValues(ca.Namespace, ca.Name, ca.Status.ActiveImage).Set(1)
+}
+
+// conditionObserver publishes the ClusterAutoscaler's status conditions,
+// derived from the status subsystem, as gauges.
+type conditionObserver struct {
+	gauge *prometheus.GaugeVec
+}
+
+func (o *conditionObserver) Refresh(ctx context.Context, ca *v1alpha1.ClusterAutoscaler) {
+	for _, c := range ca.Status.Conditions {
+		o.gauge.WithLabelValues(ca.Namespace, ca.Name, string(c.Type)).Set(conditionValue(c.Status))
+	}
+}
+
+func conditionValue(s corev1.ConditionStatus) float64 {
+	switch s {
+	case corev1.ConditionTrue:
+		return 1
+	case corev1.ConditionFalse:
+		return 0
+	default:
+		return -1
+	}
+}
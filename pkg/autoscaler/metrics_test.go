@@ -0,0 +1,73 @@
+package autoscaler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func gaugeValue(t *testing.T, gauge *prometheus.GaugeVec, labels ...string) float64 {
+	t.Helper()
+
+	metric, err := gauge.GetMetricWithLabelValues(labels...)
+	if err != nil {
+		t.Fatalf("GetMetricWithLabelValues(%v) error = %v", labels, err)
+	}
+
+	m := &dto.Metric{}
+	if err := metric.Write(m); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	return m.GetGauge().GetValue()
+}
+
+func TestLastImageObserverClearsStaleSeries(t *testing.T) {
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "test_last_observed_image",
+	}, []string{"namespace", "name", "image"})
+
+	observer := newLastImageObserver(gauge)
+	ca := newTestClusterAutoscaler()
+
+	ca.Status.ActiveImage = "quay.io/example/cluster-autoscaler:v1"
+	observer.Refresh(context.Background(), ca)
+
+	if v := gaugeValue(t, gauge, ca.Namespace, ca.Name, "quay.io/example/cluster-autoscaler:v1"); v != 1 {
+		t.Errorf("gauge for v1 = %v, want 1", v)
+	}
+
+	ca.Status.ActiveImage = "quay.io/example/cluster-autoscaler:v2"
+	observer.Refresh(context.Background(), ca)
+
+	if v := gaugeValue(t, gauge, ca.Namespace, ca.Name, "quay.io/example/cluster-autoscaler:v2"); v != 1 {
+		t.Errorf("gauge for v2 = %v, want 1", v)
+	}
+
+	// DeleteLabelValues removed the v1 series; GetMetricWithLabelValues
+	// lazily recreates it at the zero value, which is how we distinguish
+	// "deleted" from "still stuck at 1".
+	if v := gaugeValue(t, gauge, ca.Namespace, ca.Name, "quay.io/example/cluster-autoscaler:v1"); v != 0 {
+		t.Errorf("stale series for v1 = %v, want 0 (deleted) after the active image changed", v)
+	}
+}
+
+func TestConditionValue(t *testing.T) {
+	cases := []struct {
+		status corev1.ConditionStatus
+		want   float64
+	}{
+		{corev1.ConditionTrue, 1},
+		{corev1.ConditionFalse, 0},
+		{corev1.ConditionUnknown, -1},
+	}
+
+	for _, c := range cases {
+		if got := conditionValue(c.status); got != c.want {
+			t.Errorf("conditionValue(%q) = %v, want %v", c.status, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package autoscaler
+
+import (
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// updateStatus derives the ClusterAutoscaler's status conditions from the
+// status of the Deployment it manages, and records them on the CR so
+// callers can rely on conditions rather than inspecting the Deployment
+// themselves. ready reflects the aggregated readiness of the Deployment
+// and the Pods it owns, as determined by pkg/statuscheck.
+func updateStatus(ca *v1alpha1.ClusterAutoscaler, dep *appsv1.Deployment, ready bool) {
+	// Progressing starts out Unknown and takes the Deployment's own
+	// Progressing condition if it has one. It is set once, so its
+	// LastTransitionTime only moves when its status really changes.
+	progressing := v1alpha1.ClusterAutoscalerCondition{
+		Type:    v1alpha1.ClusterAutoscalerProgressing,
+		Status:  corev1.ConditionUnknown,
+		Reason:  "Deploying",
+		Message: "Waiting for the cluster-autoscaler deployment to report status",
+	}
+
+	for _, c := range dep.Status.Conditions {
+		switch c.Type {
+		case appsv1.DeploymentProgressing:
+			progressing.Status = corev1.ConditionStatus(c.Status)
+			progressing.Reason = c.Reason
+			progressing.Message = c.Message
+		case appsv1.DeploymentReplicaFailure:
+			ca.Status.SetCondition(v1alpha1.ClusterAutoscalerCondition{
+				Type:    v1alpha1.ClusterAutoscalerReplicaFailure,
+				Status:  corev1.ConditionStatus(c.Status),
+				Reason:  c.Reason,
+				Message: c.Message,
+			})
+		}
+	}
+
+	ca.Status.SetCondition(progressing)
+
+	available := corev1.ConditionFalse
+	reason := "DeploymentUnavailable"
+	message := "The cluster-autoscaler deployment does not yet have any ready replicas"
+
+	if ready {
+		available = corev1.ConditionTrue
+		reason = "DeploymentAvailable"
+		message = "The cluster-autoscaler deployment has the desired number of ready replicas"
+	}
+
+	ca.Status.SetCondition(v1alpha1.ClusterAutoscalerCondition{
+		Type:    v1alpha1.ClusterAutoscalerAvailable,
+		Status:  available,
+		Reason:  reason,
+		Message: message,
+	})
+}
@@ -0,0 +1,71 @@
+package autoscaler
+
+import (
+	"testing"
+
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestUpdateStatusAvailable(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	dep := autoscalerDeployment(ca)
+
+	updateStatus(ca, dep, true)
+
+	if !ca.Status.AvailableAndUpdated() {
+		t.Errorf("AvailableAndUpdated() = false, want true after updateStatus with ready=true")
+	}
+
+	available := ca.Status.GetCondition(v1alpha1.ClusterAutoscalerAvailable)
+	if available == nil || available.Status != corev1.ConditionTrue {
+		t.Errorf("Available condition = %v, want status True", available)
+	}
+}
+
+func TestUpdateStatusNotReady(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	dep := autoscalerDeployment(ca)
+
+	updateStatus(ca, dep, false)
+
+	if ca.Status.AvailableAndUpdated() {
+		t.Errorf("AvailableAndUpdated() = true, want false after updateStatus with ready=false")
+	}
+
+	available := ca.Status.GetCondition(v1alpha1.ClusterAutoscalerAvailable)
+	if available == nil || available.Status != corev1.ConditionFalse {
+		t.Errorf("Available condition = %v, want status False", available)
+	}
+}
+
+func TestSetConditionPreservesTransitionTime(t *testing.T) {
+	status := &v1alpha1.ClusterAutoscalerStatus{}
+
+	status.SetCondition(v1alpha1.ClusterAutoscalerCondition{
+		Type:   v1alpha1.ClusterAutoscalerDegraded,
+		Status: corev1.ConditionTrue,
+		Reason: "ConfigurationError",
+	})
+
+	first := status.GetCondition(v1alpha1.ClusterAutoscalerDegraded)
+	if first == nil {
+		t.Fatalf("GetCondition() = nil, want the condition just set")
+	}
+	firstTransition := first.LastTransitionTime
+
+	status.SetCondition(v1alpha1.ClusterAutoscalerCondition{
+		Type:    v1alpha1.ClusterAutoscalerDegraded,
+		Status:  corev1.ConditionTrue,
+		Reason:  "ConfigurationError",
+		Message: "updated message",
+	})
+
+	second := status.GetCondition(v1alpha1.ClusterAutoscalerDegraded)
+	if second.LastTransitionTime != firstTransition {
+		t.Errorf("LastTransitionTime changed without a status transition")
+	}
+	if second.Message != "updated message" {
+		t.Errorf("Message = %q, want %q", second.Message, "updated message")
+	}
+}
@@ -0,0 +1,92 @@
+package autoscaler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Event reasons emitted while coordinating an autoscaler image upgrade.
+const (
+	UpgradeStarted   = "UpgradeStarted"
+	UpgradeSkipped   = "UpgradeSkipped"
+	UpgradeCompleted = "UpgradeCompleted"
+)
+
+// intendedImage returns the image the ClusterAutoscaler should run,
+// falling back to the operator's default when the CR doesn't pin one.
+func intendedImage(ca *v1alpha1.ClusterAutoscaler) string {
+	if ca.Spec.Image != nil && *ca.Spec.Image != "" {
+		return *ca.Spec.Image
+	}
+
+	return caImage
+}
+
+// rolloutInProgress reports whether dep's previous rollout hasn't finished
+// yet, i.e. not every replica has been updated to the current template.
+// Starting a new rollout on top of an in-progress one makes it impossible
+// to tell which image a given pod is actually running.
+func rolloutInProgress(dep *appsv1.Deployment) bool {
+	return dep.Status.UpdatedReplicas != dep.Status.Replicas
+}
+
+// isDowngrade reports whether intended is an older version than active.
+// Versions are compared as dot-separated integers (e.g. "1.14.2"); if
+// either side doesn't parse that way, the comparison is inconclusive and
+// isDowngrade returns false so the write is not blocked on a guess.
+func isDowngrade(active, intended string) bool {
+	a, ok := parseVersion(active)
+	if !ok {
+		return false
+	}
+
+	i, ok := parseVersion(intended)
+	if !ok {
+		return false
+	}
+
+	for idx := 0; idx < len(a) && idx < len(i); idx++ {
+		if i[idx] != a[idx] {
+			return i[idx] < a[idx]
+		}
+	}
+
+	return len(i) < len(a)
+}
+
+// parseVersion extracts the version tag from an image reference (the
+// portion after the final ":") and splits it into dot-separated integer
+// components.
+func parseVersion(image string) ([]int, bool) {
+	tag := image
+	if idx := strings.LastIndex(image, ":"); idx != -1 {
+		tag = image[idx+1:]
+	}
+
+	parts := strings.Split(tag, ".")
+	nums := make([]int, len(parts))
+
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		nums[i] = n
+	}
+
+	return nums, true
+}
+
+// recordUpgradeEvent emits a Kubernetes event for ca describing an upgrade
+// coordination decision, if h has an event recorder configured.
+func (h *Handler) recordUpgradeEvent(ca *v1alpha1.ClusterAutoscaler, reason, message string) {
+	if h.recorder == nil {
+		return
+	}
+
+	h.recorder.Event(ca, corev1.EventTypeNormal, reason, message)
+}
@@ -0,0 +1,58 @@
+package autoscaler
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func TestIsDowngrade(t *testing.T) {
+	cases := []struct {
+		name     string
+		active   string
+		intended string
+		want     bool
+	}{
+		{"older patch", "quay.io/bison/cluster-autoscaler:1.14.2", "quay.io/bison/cluster-autoscaler:1.14.1", true},
+		{"newer patch", "quay.io/bison/cluster-autoscaler:1.14.1", "quay.io/bison/cluster-autoscaler:1.14.2", false},
+		{"same version", "quay.io/bison/cluster-autoscaler:1.14.2", "quay.io/bison/cluster-autoscaler:1.14.2", false},
+		{"unparseable active", "quay.io/bison/cluster-autoscaler:a554b4f5", "quay.io/bison/cluster-autoscaler:1.14.2", false},
+		{"unparseable intended", "quay.io/bison/cluster-autoscaler:1.14.2", "quay.io/bison/cluster-autoscaler:a554b4f5", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isDowngrade(c.active, c.intended); got != c.want {
+				t.Errorf("isDowngrade(%q, %q) = %v, want %v", c.active, c.intended, got, c.want)
+			}
+		})
+	}
+}
+
+func TestRolloutInProgress(t *testing.T) {
+	dep := &appsv1.Deployment{}
+	dep.Status.Replicas = 2
+	dep.Status.UpdatedReplicas = 1
+
+	if !rolloutInProgress(dep) {
+		t.Errorf("rolloutInProgress() = false, want true when UpdatedReplicas < Replicas")
+	}
+
+	dep.Status.UpdatedReplicas = 2
+	if rolloutInProgress(dep) {
+		t.Errorf("rolloutInProgress() = true, want false when all replicas are updated")
+	}
+}
+
+func TestIntendedImageDefault(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+
+	if got := intendedImage(ca); got != caImage {
+		t.Errorf("intendedImage() = %q, want default %q", got, caImage)
+	}
+
+	ca.Spec.Image = stringPtr("quay.io/example/cluster-autoscaler:v1")
+	if got := intendedImage(ca); got != "quay.io/example/cluster-autoscaler:v1" {
+		t.Errorf("intendedImage() = %q, want spec override", got)
+	}
+}
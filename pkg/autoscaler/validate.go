@@ -0,0 +1,45 @@
+package autoscaler
+
+import (
+	"time"
+
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+)
+
+// validateSpec checks ca's spec for values that are invalid on their face,
+// i.e. problems a spec change would be required to fix. These are reported
+// as a ConfigurationError, which backs Handle off from retrying at the same
+// rate as a transient API error.
+func validateSpec(ca *v1alpha1.ClusterAutoscaler) *AutoscalerError {
+	if rl := ca.Spec.ResourceLimits; rl != nil {
+		if rl.Cores != nil && rl.Cores.Min > rl.Cores.Max {
+			return NewAutoscalerError(ConfigurationError, "resourceLimits.cores.min (%d) is greater than resourceLimits.cores.max (%d)", rl.Cores.Min, rl.Cores.Max)
+		}
+
+		if rl.Memory != nil && rl.Memory.Min > rl.Memory.Max {
+			return NewAutoscalerError(ConfigurationError, "resourceLimits.memory.min (%d) is greater than resourceLimits.memory.max (%d)", rl.Memory.Min, rl.Memory.Max)
+		}
+
+		for _, gpu := range rl.GPUS {
+			if gpu.Min > gpu.Max {
+				return NewAutoscalerError(ConfigurationError, "resourceLimits.gpus[%s].min (%d) is greater than resourceLimits.gpus[%s].max (%d)", gpu.Type, gpu.Min, gpu.Type, gpu.Max)
+			}
+		}
+	}
+
+	if sd := ca.Spec.ScaleDown; sd != nil {
+		if sd.DelayAfterAdd != nil {
+			if _, err := time.ParseDuration(*sd.DelayAfterAdd); err != nil {
+				return NewAutoscalerError(ConfigurationError, "scaleDown.delayAfterAdd %q is not a valid duration: %v", *sd.DelayAfterAdd, err)
+			}
+		}
+
+		if sd.UnneededTime != nil {
+			if _, err := time.ParseDuration(*sd.UnneededTime); err != nil {
+				return NewAutoscalerError(ConfigurationError, "scaleDown.unneededTime %q is not a valid duration: %v", *sd.UnneededTime, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package autoscaler
+
+import "testing"
+
+func TestValidateSpecValid(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+
+	if err := validateSpec(ca); err != nil {
+		t.Errorf("validateSpec() = %v, want nil for a valid spec", err)
+	}
+}
+
+func TestValidateSpecInvalidCoresRange(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	ca.Spec.ResourceLimits.Cores.Min = 128
+	ca.Spec.ResourceLimits.Cores.Max = 8
+
+	err := validateSpec(ca)
+	if err == nil {
+		t.Fatalf("validateSpec() = nil, want a ConfigurationError for cores.min > cores.max")
+	}
+	if err.Type != ConfigurationError {
+		t.Errorf("validateSpec() error type = %v, want %v", err.Type, ConfigurationError)
+	}
+}
+
+func TestValidateSpecInvalidScaleDownDuration(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	ca.Spec.ScaleDown.UnneededTime = stringPtr("not-a-duration")
+
+	err := validateSpec(ca)
+	if err == nil {
+		t.Fatalf("validateSpec() = nil, want a ConfigurationError for an unparseable duration")
+	}
+	if err.Type != ConfigurationError {
+		t.Errorf("validateSpec() error type = %v, want %v", err.Type, ConfigurationError)
+	}
+}
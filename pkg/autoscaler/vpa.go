@@ -0,0 +1,127 @@
+package autoscaler
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+)
+
+// RegisterVPAScheme adds the VerticalPodAutoscaler types to scheme, so that
+// sdk.Create/sdk.Get can (de)serialize them. Operators that don't call this
+// simply won't be able to use the spec.verticalPodAutoscaler field.
+func RegisterVPAScheme(scheme *runtime.Scheme) error {
+	return vpav1.AddToScheme(scheme)
+}
+
+// vpaUpdateMode returns the update mode configured for ca's
+// VerticalPodAutoscaler, defaulting to Auto when the field is unset.
+func vpaUpdateMode(ca *v1alpha1.ClusterAutoscaler) v1alpha1.VPAUpdateMode {
+	vpaConfig := ca.Spec.VerticalPodAutoscaler
+	if vpaConfig == nil || vpaConfig.UpdateMode == nil {
+		return v1alpha1.VPAUpdateModeAuto
+	}
+
+	return *vpaConfig.UpdateMode
+}
+
+// autoscalerVPA builds the VerticalPodAutoscaler for ca's cluster-autoscaler
+// Deployment.
+func autoscalerVPA(ca *v1alpha1.ClusterAutoscaler) *vpav1.VerticalPodAutoscaler {
+	deploymentName := fmt.Sprintf("cluster-autoscaler-%s", ca.Name)
+	mode := vpav1.UpdateMode(vpaUpdateMode(ca))
+
+	vpa := &vpav1.VerticalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "autoscaling.k8s.io/v1",
+			Kind:       "VerticalPodAutoscaler",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploymentName,
+			Namespace: ca.Namespace,
+		},
+		Spec: vpav1.VerticalPodAutoscalerSpec{
+			TargetRef: &autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       deploymentName,
+			},
+			UpdatePolicy: &vpav1.PodUpdatePolicy{
+				UpdateMode: &mode,
+			},
+		},
+	}
+
+	if vpaConfig := ca.Spec.VerticalPodAutoscaler; vpaConfig != nil && (vpaConfig.MinAllowed != nil || vpaConfig.MaxAllowed != nil) {
+		vpa.Spec.ResourcePolicy = &vpav1.PodResourcePolicy{
+			ContainerPolicies: []vpav1.ContainerResourcePolicy{
+				{
+					ContainerName: "cluster-autoscaler",
+					MinAllowed:    vpaConfig.MinAllowed,
+					MaxAllowed:    vpaConfig.MaxAllowed,
+				},
+			},
+		}
+	}
+
+	addOwnerRefToObject(vpa, asOwner(ca))
+
+	return vpa
+}
+
+// reconcileVPA creates or updates the VerticalPodAutoscaler for ca's
+// cluster-autoscaler Deployment when ca opts into one, and is a no-op
+// otherwise.
+func (h *Handler) reconcileVPA(ca *v1alpha1.ClusterAutoscaler) error {
+	if ca.Spec.VerticalPodAutoscaler == nil {
+		return nil
+	}
+
+	vpa := autoscalerVPA(ca)
+	err := h.client.Create(vpa)
+	if err == nil || errors.IsAlreadyExists(err) {
+		if errors.IsAlreadyExists(err) {
+			if err := h.client.Get(vpa); err != nil {
+				return NewAutoscalerError(ApiCallError, "failed to get autoscaler VerticalPodAutoscaler: %v", err)
+			}
+		}
+
+		desired := autoscalerVPA(ca)
+		if reflect.DeepEqual(vpa.Spec, desired.Spec) {
+			return nil
+		}
+
+		vpa.Spec = desired.Spec
+		if err := h.client.Update(vpa); err != nil {
+			return NewAutoscalerError(ApiCallError, "failed to update autoscaler VerticalPodAutoscaler: %v", err)
+		}
+
+		return nil
+	}
+
+	return NewAutoscalerError(ApiCallError, "failed to create autoscaler VerticalPodAutoscaler: %v", err)
+}
+
+// defaultResources returns the default resource requests/limits for the
+// cluster-autoscaler container. When a VerticalPodAutoscaler manages the
+// container in Auto mode, the operator stops setting its own resources so
+// it doesn't fight the VPA's evict-and-resize cycle.
+func defaultResources(ca *v1alpha1.ClusterAutoscaler) corev1.ResourceRequirements {
+	if vpaUpdateMode(ca) == v1alpha1.VPAUpdateModeAuto && ca.Spec.VerticalPodAutoscaler != nil {
+		return corev1.ResourceRequirements{}
+	}
+
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("300Mi"),
+		},
+	}
+}
@@ -0,0 +1,87 @@
+package autoscaler
+
+import (
+	"testing"
+
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestVpaUpdateModeDefault(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+
+	if got := vpaUpdateMode(ca); got != v1alpha1.VPAUpdateModeAuto {
+		t.Errorf("vpaUpdateMode() = %v, want %v when unset", got, v1alpha1.VPAUpdateModeAuto)
+	}
+}
+
+func TestVpaUpdateModeExplicit(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	off := v1alpha1.VPAUpdateModeOff
+	ca.Spec.VerticalPodAutoscaler = &v1alpha1.VerticalPodAutoscalerConfig{UpdateMode: &off}
+
+	if got := vpaUpdateMode(ca); got != v1alpha1.VPAUpdateModeOff {
+		t.Errorf("vpaUpdateMode() = %v, want %v", got, v1alpha1.VPAUpdateModeOff)
+	}
+}
+
+func TestAutoscalerVPATargetsOwnDeployment(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	vpa := autoscalerVPA(ca)
+
+	wantName := "cluster-autoscaler-" + ca.Name
+	if vpa.Name != wantName {
+		t.Errorf("vpa.Name = %q, want %q", vpa.Name, wantName)
+	}
+	if vpa.Spec.TargetRef.Name != wantName {
+		t.Errorf("TargetRef.Name = %q, want %q", vpa.Spec.TargetRef.Name, wantName)
+	}
+	if vpa.Spec.TargetRef.Kind != "Deployment" {
+		t.Errorf("TargetRef.Kind = %q, want %q", vpa.Spec.TargetRef.Kind, "Deployment")
+	}
+}
+
+func TestAutoscalerVPAResourcePolicy(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	ca.Spec.VerticalPodAutoscaler = &v1alpha1.VerticalPodAutoscalerConfig{
+		MinAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+	}
+
+	vpa := autoscalerVPA(ca)
+	if vpa.Spec.ResourcePolicy == nil {
+		t.Fatalf("ResourcePolicy = nil, want a policy when MinAllowed is set")
+	}
+	if len(vpa.Spec.ResourcePolicy.ContainerPolicies) != 1 {
+		t.Fatalf("ContainerPolicies = %v, want exactly one entry", vpa.Spec.ResourcePolicy.ContainerPolicies)
+	}
+}
+
+func TestAutoscalerVPANoResourcePolicyByDefault(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	vpa := autoscalerVPA(ca)
+
+	if vpa.Spec.ResourcePolicy != nil {
+		t.Errorf("ResourcePolicy = %v, want nil when MinAllowed/MaxAllowed are unset", vpa.Spec.ResourcePolicy)
+	}
+}
+
+func TestDefaultResourcesSkippedUnderAutoVPA(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+	ca.Spec.VerticalPodAutoscaler = &v1alpha1.VerticalPodAutoscalerConfig{}
+
+	resources := defaultResources(ca)
+	if len(resources.Requests) != 0 || len(resources.Limits) != 0 {
+		t.Errorf("defaultResources() = %v, want empty under Auto VPA", resources)
+	}
+}
+
+func TestDefaultResourcesSetWithoutVPA(t *testing.T) {
+	ca := newTestClusterAutoscaler()
+
+	resources := defaultResources(ca)
+	if resources.Requests == nil {
+		t.Errorf("defaultResources() = %v, want default requests set without a VPA", resources)
+	}
+}
+
@@ -0,0 +1,35 @@
+// Package loopstart provides a pluggable hook invoked at the start of every
+// reconcile pass for a ClusterAutoscaler. It lets metrics, logging, or
+// other observations be collected without the reconciler growing a new
+// concern for each one.
+package loopstart
+
+import (
+	"context"
+
+	"github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1alpha1"
+)
+
+// Observer is notified at the start of every reconcile pass.
+type Observer interface {
+	Refresh(ctx context.Context, ca *v1alpha1.ClusterAutoscaler)
+}
+
+// ObserversList fans a single Refresh call out to every registered
+// Observer. The zero value is ready to use.
+type ObserversList struct {
+	observers []Observer
+}
+
+// Register adds o to the list of observers invoked by Refresh.
+func (l *ObserversList) Register(o Observer) {
+	l.observers = append(l.observers, o)
+}
+
+// Refresh invokes Refresh on every registered observer, in the order they
+// were registered.
+func (l *ObserversList) Refresh(ctx context.Context, ca *v1alpha1.ClusterAutoscaler) {
+	for _, o := range l.observers {
+		o.Refresh(ctx, ca)
+	}
+}
@@ -0,0 +1,211 @@
+// Package statuscheck implements readiness checks for the resources owned
+// by a cluster-autoscaler Deployment, modeled on the kstatus-based ready
+// checker used by Helm 3.5. A Deployment's own status fields routinely
+// report "ready" while the Pods it owns are still crash-looping or waiting
+// on RBAC, so the predicates here walk the owned Pods and ReplicaSets
+// directly instead of trusting DeploymentStatus alone.
+package statuscheck
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/operator-framework/operator-sdk/pkg/sdk"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// MaxContainerRestarts is the number of times a container is allowed to
+// have restarted before it is considered unhealthy rather than merely
+// recovering from a transient failure.
+const MaxContainerRestarts = 5
+
+// PollInterval is how often Wait re-checks readiness.
+const PollInterval = 2 * time.Second
+
+// DeploymentReady reports whether dep is ready: its ServiceAccount exists,
+// its current ReplicaSet matches its observed generation, and every Pod in
+// pods has every container reporting Ready within MaxContainerRestarts.
+// The Pods are not filtered by owner: Ready passes every Pod matching the
+// Deployment's selector.
+func DeploymentReady(dep *appsv1.Deployment, sa *corev1.ServiceAccount, pods []corev1.Pod, replicaSets []appsv1.ReplicaSet) (bool, error) {
+	if !ServiceAccountReady(sa) {
+		return false, nil
+	}
+
+	if dep.Status.ObservedGeneration < dep.Generation {
+		return false, nil
+	}
+
+	rs := currentReplicaSet(dep, replicaSets)
+	if rs == nil || !ReplicaSetReady(rs) {
+		return false, nil
+	}
+
+	for i := range pods {
+		ready, err := PodReady(&pods[i])
+		if err != nil {
+			return false, err
+		}
+		if !ready {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// currentReplicaSet returns the ReplicaSet owned by dep whose observed
+// generation is at least as new as the Deployment's, or nil if none is
+// found.
+func currentReplicaSet(dep *appsv1.Deployment, replicaSets []appsv1.ReplicaSet) *appsv1.ReplicaSet {
+	for i := range replicaSets {
+		rs := &replicaSets[i]
+
+		owned := false
+		for _, ref := range rs.OwnerReferences {
+			if ref.UID == dep.UID {
+				owned = true
+				break
+			}
+		}
+
+		if owned && rs.Status.ObservedGeneration >= dep.Status.ObservedGeneration {
+			return rs
+		}
+	}
+
+	return nil
+}
+
+// ReplicaSetReady reports whether rs has its desired number of available
+// replicas.
+func ReplicaSetReady(rs *appsv1.ReplicaSet) bool {
+	if rs.Spec.Replicas == nil {
+		return rs.Status.AvailableReplicas > 0
+	}
+
+	return rs.Status.AvailableReplicas >= *rs.Spec.Replicas
+}
+
+// PodReady reports whether every container in pod is reporting Ready. A
+// container that has restarted more than MaxContainerRestarts times is
+// treated as an error rather than a transient not-ready state, since it
+// indicates a crash loop rather than a slow start.
+func PodReady(pod *corev1.Pod) (bool, error) {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false, nil
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.RestartCount > MaxContainerRestarts {
+			return false, fmt.Errorf("container %q in pod %q has restarted %d times", cs.Name, pod.Name, cs.RestartCount)
+		}
+
+		if !cs.Ready {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// ServiceAccountReady reports whether the given ServiceAccount exists.
+// ServiceAccounts have no further readiness semantics of their own, but
+// other owned resources (e.g. the autoscaler Pod) may be blocked waiting
+// on one to be created.
+func ServiceAccountReady(sa *corev1.ServiceAccount) bool {
+	return sa != nil
+}
+
+// Ready fetches the Deployment named name in namespace, along with its
+// ServiceAccount and the Pods and ReplicaSets it owns, and reports their
+// aggregated readiness. A ServiceAccount or Deployment that doesn't exist
+// yet is reported as not-ready rather than as an error, since that's the
+// normal state immediately after creation; any other lookup failure, or a
+// Pod crash-looping past MaxContainerRestarts, is returned as an error so
+// the caller can tell "still coming up" apart from "something is wrong".
+func Ready(name, namespace, serviceAccountName string) (bool, error) {
+	dep := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	if err := sdk.Get(dep); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get deployment %s/%s: %v", namespace, name, err)
+	}
+
+	sa := &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceAccountName,
+			Namespace: namespace,
+		},
+	}
+
+	if err := sdk.Get(sa); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get service account %s/%s: %v", namespace, serviceAccountName, err)
+	}
+
+	selector := labels.Set(dep.Spec.Selector.MatchLabels).String()
+	listOpts := sdk.WithListOptions(&metav1.ListOptions{LabelSelector: selector})
+
+	pods := &corev1.PodList{}
+	if err := sdk.List(namespace, pods, listOpts); err != nil {
+		return false, fmt.Errorf("failed to list pods owned by %s/%s: %v", namespace, name, err)
+	}
+
+	replicaSets := &appsv1.ReplicaSetList{}
+	if err := sdk.List(namespace, replicaSets, listOpts); err != nil {
+		return false, fmt.Errorf("failed to list replica sets owned by %s/%s: %v", namespace, name, err)
+	}
+
+	return DeploymentReady(dep, sa, pods.Items, replicaSets.Items)
+}
+
+// Wait polls Ready for the Deployment named name in namespace until it
+// reports ready, ready returns a hard error (e.g. a crash-looping Pod),
+// ctx is cancelled, or timeout elapses. The last readiness observed is
+// always returned, even on timeout, so callers can record it rather than
+// treating a timeout as a bare failure.
+func Wait(ctx context.Context, timeout time.Duration, name, namespace, serviceAccountName string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var ready bool
+
+	pollErr := wait.PollImmediateUntil(PollInterval, func() (bool, error) {
+		r, err := Ready(name, namespace, serviceAccountName)
+		if err != nil {
+			return false, err
+		}
+		ready = r
+		return ready, nil
+	}, ctx.Done())
+
+	if pollErr != nil && pollErr != wait.ErrWaitTimeout {
+		return ready, pollErr
+	}
+
+	return ready, nil
+}
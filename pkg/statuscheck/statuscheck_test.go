@@ -0,0 +1,133 @@
+package statuscheck
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newReadyDeployment() *appsv1.Deployment {
+	var replicas int32 = 1
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{UID: types.UID("dep-uid")},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+		},
+	}
+}
+
+func newReadyReplicaSet(depUID types.UID) appsv1.ReplicaSet {
+	var replicas int32 = 1
+
+	return appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			OwnerReferences: []metav1.OwnerReference{{UID: depUID}},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.ReplicaSetStatus{
+			ObservedGeneration: 1,
+			AvailableReplicas:  1,
+		},
+	}
+}
+
+func newReadyPod() corev1.Pod {
+	return corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "cluster-autoscaler", Ready: true},
+			},
+		},
+	}
+}
+
+func TestDeploymentReady(t *testing.T) {
+	dep := newReadyDeployment()
+	sa := &corev1.ServiceAccount{}
+	rs := newReadyReplicaSet(dep.UID)
+	pod := newReadyPod()
+
+	ready, err := DeploymentReady(dep, sa, []corev1.Pod{pod}, []appsv1.ReplicaSet{rs})
+	if err != nil {
+		t.Fatalf("DeploymentReady() error = %v, want nil", err)
+	}
+	if !ready {
+		t.Errorf("DeploymentReady() = false, want true")
+	}
+}
+
+func TestDeploymentReadyMissingServiceAccount(t *testing.T) {
+	dep := newReadyDeployment()
+	rs := newReadyReplicaSet(dep.UID)
+	pod := newReadyPod()
+
+	ready, err := DeploymentReady(dep, nil, []corev1.Pod{pod}, []appsv1.ReplicaSet{rs})
+	if err != nil {
+		t.Fatalf("DeploymentReady() error = %v, want nil", err)
+	}
+	if ready {
+		t.Errorf("DeploymentReady() = true, want false when the ServiceAccount is missing")
+	}
+}
+
+func TestDeploymentReadyCrashLoop(t *testing.T) {
+	dep := newReadyDeployment()
+	sa := &corev1.ServiceAccount{}
+	rs := newReadyReplicaSet(dep.UID)
+
+	pod := newReadyPod()
+	pod.Status.ContainerStatuses[0].RestartCount = MaxContainerRestarts + 1
+
+	_, err := DeploymentReady(dep, sa, []corev1.Pod{pod}, []appsv1.ReplicaSet{rs})
+	if err == nil {
+		t.Fatalf("DeploymentReady() error = nil, want an error for a crash-looping container")
+	}
+}
+
+func TestDeploymentReadyStaleObservedGeneration(t *testing.T) {
+	dep := newReadyDeployment()
+	dep.Generation = 2
+	sa := &corev1.ServiceAccount{}
+	rs := newReadyReplicaSet(dep.UID)
+	pod := newReadyPod()
+
+	ready, err := DeploymentReady(dep, sa, []corev1.Pod{pod}, []appsv1.ReplicaSet{rs})
+	if err != nil {
+		t.Fatalf("DeploymentReady() error = %v, want nil", err)
+	}
+	if ready {
+		t.Errorf("DeploymentReady() = true, want false when ObservedGeneration lags Generation")
+	}
+}
+
+func TestPodReadyNotRunning(t *testing.T) {
+	pod := newReadyPod()
+	pod.Status.Phase = corev1.PodPending
+
+	ready, err := PodReady(&pod)
+	if err != nil {
+		t.Fatalf("PodReady() error = %v, want nil", err)
+	}
+	if ready {
+		t.Errorf("PodReady() = true, want false for a pending pod")
+	}
+}
+
+func TestServiceAccountReady(t *testing.T) {
+	if ServiceAccountReady(nil) {
+		t.Errorf("ServiceAccountReady(nil) = true, want false")
+	}
+	if !ServiceAccountReady(&corev1.ServiceAccount{}) {
+		t.Errorf("ServiceAccountReady() = false, want true for an existing ServiceAccount")
+	}
+}